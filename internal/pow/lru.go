@@ -0,0 +1,54 @@
+package pow
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a small fixed-capacity set used to reject replayed seeds
+// without growing unbounded.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	index    map[string]*list.Element
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		order:    list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) Contains(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.index[key]
+	return ok
+}
+
+// CheckAndSet atomically checks whether key is already present and, if not,
+// inserts it, all under a single lock. It reports whether the key was
+// already present, so a caller checking for replay can treat "already
+// present" as rejection without a separate Contains/Add pair racing against
+// a concurrent caller doing the same thing.
+func (c *lruCache) CheckAndSet(key string) (alreadyPresent bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.index[key]; ok {
+		return true
+	}
+
+	c.index[key] = c.order.PushFront(key)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.index, oldest.Value.(string))
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package pow
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ChallengeHandler serves GET /pow/challenge with a freshly issued puzzle.
+func ChallengeHandler(v *Verifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v.Issue())
+	}
+}
+
+// Middleware requires a valid X-PoW-Solution header before forwarding the
+// request to next. On failure it responds 429 with a fresh challenge so
+// the client can immediately retry.
+func Middleware(v *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			solution := r.Header.Get("X-PoW-Solution")
+			if solution == "" || v.Verify(solution) != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(w).Encode(v.Issue())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
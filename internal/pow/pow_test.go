@@ -0,0 +1,158 @@
+package pow
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// solve brute-forces a nonce that satisfies the challenge's difficulty, for
+// use in tests where we can't do real proof-of-work at a realistic bit count.
+func solve(t *testing.T, seed string, difficulty int) string {
+	t.Helper()
+	for n := 0; n < 1_000_000; n++ {
+		nonce := fmt.Sprintf("%d", n)
+		if hasLeadingZeroBits(sha256.Sum256([]byte(seed+":"+nonce)), difficulty) {
+			return nonce
+		}
+	}
+	t.Fatalf("solve: no nonce found under 1,000,000 attempts at difficulty %d", difficulty)
+	return ""
+}
+
+func TestVerifyAcceptsAValidSolution(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, time.Minute)
+	ch := v.Issue()
+
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+	if err := v.Verify(ch.Seed + ":" + nonce); err != nil {
+		t.Fatalf("Verify() error = %v, want nil", err)
+	}
+}
+
+func TestVerifyRejectsReplayedSolution(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, time.Minute)
+	ch := v.Issue()
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+	solution := ch.Seed + ":" + nonce
+
+	if err := v.Verify(solution); err != nil {
+		t.Fatalf("first Verify() error = %v, want nil", err)
+	}
+	if err := v.Verify(solution); err != ErrReplayed {
+		t.Fatalf("second Verify() error = %v, want ErrReplayed", err)
+	}
+}
+
+func TestVerifyRejectsConcurrentReplayOfSameSolution(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, time.Minute)
+	ch := v.Issue()
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+	solution := ch.Seed + ":" + nonce
+
+	const attempts = 20
+	results := make(chan error, attempts)
+
+	var start sync.WaitGroup
+	start.Add(1)
+	for i := 0; i < attempts; i++ {
+		go func() {
+			start.Wait()
+			results <- v.Verify(solution)
+		}()
+	}
+	start.Done()
+
+	var accepted, replayed int
+	for i := 0; i < attempts; i++ {
+		switch err := <-results; err {
+		case nil:
+			accepted++
+		case ErrReplayed:
+			replayed++
+		default:
+			t.Fatalf("Verify() error = %v, want nil or ErrReplayed", err)
+		}
+	}
+
+	if accepted != 1 {
+		t.Fatalf("accepted = %d concurrent Verify() calls, want exactly 1", accepted)
+	}
+	if replayed != attempts-1 {
+		t.Fatalf("replayed = %d, want %d", replayed, attempts-1)
+	}
+}
+
+func TestVerifyRejectsExpiredChallenge(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, -time.Second)
+	ch := v.Issue()
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+
+	if err := v.Verify(ch.Seed + ":" + nonce); err != ErrExpired {
+		t.Fatalf("Verify() error = %v, want ErrExpired", err)
+	}
+}
+
+func TestVerifyRejectsTamperedSeed(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, time.Minute)
+	ch := v.Issue()
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+
+	tampered := ch.Seed + "x"
+	if err := v.Verify(tampered + ":" + nonce); err != ErrBadSignature {
+		t.Fatalf("Verify() error = %v, want ErrBadSignature", err)
+	}
+}
+
+func TestVerifyRejectsWrongSecret(t *testing.T) {
+	issuer := NewVerifier([]byte("secret-a"), 4, time.Minute)
+	verifier := NewVerifier([]byte("secret-b"), 4, time.Minute)
+
+	ch := issuer.Issue()
+	nonce := solve(t, ch.Seed, ch.Difficulty)
+
+	if err := verifier.Verify(ch.Seed + ":" + nonce); err != ErrBadSignature {
+		t.Fatalf("Verify() error = %v, want ErrBadSignature", err)
+	}
+}
+
+func TestVerifyRejectsInsufficientWork(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 64, time.Minute)
+	ch := v.Issue()
+
+	// "0" is vanishingly unlikely to satisfy a 64-bit difficulty.
+	if err := v.Verify(ch.Seed + ":0"); err != ErrInsufficientWork {
+		t.Fatalf("Verify() error = %v, want ErrInsufficientWork", err)
+	}
+}
+
+func TestVerifyRejectsMalformedSolution(t *testing.T) {
+	v := NewVerifier([]byte("secret"), 4, time.Minute)
+
+	for _, solution := range []string{"", "no-colon-here", "seed-without-dot:nonce"} {
+		if err := v.Verify(solution); err != ErrMalformed {
+			t.Fatalf("Verify(%q) error = %v, want ErrMalformed", solution, err)
+		}
+	}
+}
+
+func TestHasLeadingZeroBits(t *testing.T) {
+	cases := []struct {
+		digest [32]byte
+		bits   int
+		want   bool
+	}{
+		{digest: [32]byte{0x00, 0xFF}, bits: 8, want: true},
+		{digest: [32]byte{0x00, 0xFF}, bits: 9, want: false},
+		{digest: [32]byte{0x0F, 0xFF}, bits: 4, want: true},
+		{digest: [32]byte{0x0F, 0xFF}, bits: 5, want: false},
+		{digest: [32]byte{0xFF}, bits: 0, want: true},
+	}
+	for _, c := range cases {
+		if got := hasLeadingZeroBits(c.digest, c.bits); got != c.want {
+			t.Errorf("hasLeadingZeroBits(%v, %d) = %v, want %v", c.digest, c.bits, got, c.want)
+		}
+	}
+}
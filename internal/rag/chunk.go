@@ -0,0 +1,31 @@
+package rag
+
+// ChunkText splits text into overlapping fixed-size chunks. size and
+// overlap are character counts; size must be greater than overlap.
+func ChunkText(text string, size, overlap int) []string {
+	if size <= 0 {
+		size = 1000
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return nil
+	}
+
+	var chunks []string
+	step := size - overlap
+	for start := 0; start < len(runes); start += step {
+		end := start + size
+		if end > len(runes) {
+			end = len(runes)
+		}
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+	}
+	return chunks
+}
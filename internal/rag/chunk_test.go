@@ -0,0 +1,69 @@
+package rag
+
+import "testing"
+
+func TestChunkTextEmpty(t *testing.T) {
+	if got := ChunkText("", 10, 2); got != nil {
+		t.Fatalf("ChunkText(\"\") = %v, want nil", got)
+	}
+}
+
+func TestChunkTextShorterThanSize(t *testing.T) {
+	got := ChunkText("hello", 10, 2)
+	want := []string{"hello"}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("ChunkText() = %v, want %v", got, want)
+	}
+}
+
+func TestChunkTextSplitsWithOverlap(t *testing.T) {
+	// 10 runes, size 4, overlap 1 -> step 3: [0:4] [3:7] [6:10]
+	got := ChunkText("0123456789", 4, 1)
+	want := []string{"0123", "3456", "6789"}
+	if len(got) != len(want) {
+		t.Fatalf("ChunkText() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ChunkText()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkTextLastChunkIsNotPaddedPastEnd(t *testing.T) {
+	got := ChunkText("0123456789", 4, 0)
+	want := []string{"0123", "4567", "89"}
+	if len(got) != len(want) {
+		t.Fatalf("ChunkText() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ChunkText()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkTextInvalidOverlapFallsBackToZero(t *testing.T) {
+	// overlap >= size is invalid and should behave like overlap 0.
+	got := ChunkText("0123456789", 4, 4)
+	want := []string{"0123", "4567", "89"}
+	if len(got) != len(want) {
+		t.Fatalf("ChunkText() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ChunkText()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestChunkTextNonPositiveSizeFallsBackToDefault(t *testing.T) {
+	text := make([]rune, 2500)
+	for i := range text {
+		text[i] = 'a'
+	}
+	got := ChunkText(string(text), 0, 0)
+	if len(got) != 3 {
+		t.Fatalf("ChunkText() produced %d chunks, want 3 at the default size of 1000", len(got))
+	}
+}
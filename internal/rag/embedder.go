@@ -0,0 +1,84 @@
+package rag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	bedrock "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+// embedConcurrency bounds how many InvokeModel calls a single Embed is
+// allowed to have in flight at once. Bedrock embeds one chunk per call, so
+// embedding a large document serially can take tens of seconds; running a
+// bounded number in parallel keeps a big upload under the server's
+// WriteTimeout without overwhelming the account's Bedrock rate limit.
+const embedConcurrency = 8
+
+// BedrockEmbedder embeds text via a Bedrock embeddings model (e.g. Titan
+// Embeddings or Cohere Embed), one InvokeModel call per input text. Calls
+// for a single Embed are parallelized up to embedConcurrency.
+type BedrockEmbedder struct {
+	client  *bedrock.Client
+	modelID string
+}
+
+// NewBedrockEmbedder builds a BedrockEmbedder against the given model.
+func NewBedrockEmbedder(client *bedrock.Client, modelID string) *BedrockEmbedder {
+	return &BedrockEmbedder{client: client, modelID: modelID}
+}
+
+func (e *BedrockEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+
+	sem := make(chan struct{}, embedConcurrency)
+	var wg sync.WaitGroup
+	errs := make([]error, len(texts))
+
+	for i, text := range texts {
+		i, text := i, text
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			vectors[i], errs[i] = e.embedOne(ctx, text)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vectors, nil
+}
+
+func (e *BedrockEmbedder) embedOne(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(map[string]string{"inputText": text})
+	if err != nil {
+		return nil, fmt.Errorf("rag: marshal embed request: %w", err)
+	}
+
+	out, err := e.client.InvokeModel(ctx, &bedrock.InvokeModelInput{
+		Body:        body,
+		ModelId:     &e.modelID,
+		ContentType: awsString("application/json"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rag: invoke embeddings model: %w", err)
+	}
+
+	var parsed struct {
+		Embedding []float32 `json:"embedding"`
+	}
+	if err := json.Unmarshal(out.Body, &parsed); err != nil {
+		return nil, fmt.Errorf("rag: parse embeddings response: %w", err)
+	}
+
+	return parsed.Embedding, nil
+}
+
+func awsString(s string) *string { return &s }
@@ -0,0 +1,48 @@
+package rag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ExtractText pulls plain text out of an uploaded document based on its
+// file extension (.txt, .md, .pdf). Unsupported extensions are rejected
+// rather than guessed at.
+func ExtractText(filename string, data []byte) (string, error) {
+	switch ext := strings.ToLower(extOf(filename)); ext {
+	case ".txt", ".md", ".markdown":
+		return string(data), nil
+	case ".pdf":
+		return extractPDFText(data)
+	default:
+		return "", fmt.Errorf("rag: unsupported file extension %q", ext)
+	}
+}
+
+func extOf(filename string) string {
+	if i := strings.LastIndex(filename, "."); i >= 0 {
+		return filename[i:]
+	}
+	return ""
+}
+
+func extractPDFText(data []byte) (string, error) {
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("rag: open pdf: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for i := 1; i <= r.NumPage(); i++ {
+		content, err := r.Page(i).GetPlainText(nil)
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("rag: read pdf page %d: %w", i, err)
+		}
+		buf.WriteString(content)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,85 @@
+package rag
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// MemoryVectorStore is a brute-force cosine-similarity VectorStore. It's
+// fine for the corpus sizes a single process can hold in RAM; for
+// anything larger, see the pgvector-backed store.
+type MemoryVectorStore struct {
+	mu     sync.RWMutex
+	chunks []Chunk
+}
+
+// NewMemoryVectorStore builds an empty MemoryVectorStore.
+func NewMemoryVectorStore() *MemoryVectorStore {
+	return &MemoryVectorStore{}
+}
+
+func (s *MemoryVectorStore) Add(ctx context.Context, chunks []Chunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = append(s.chunks, chunks...)
+	return nil
+}
+
+func (s *MemoryVectorStore) Search(ctx context.Context, query []float32, topK int) ([]Chunk, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type scored struct {
+		chunk Chunk
+		score float64
+	}
+
+	scores := make([]scored, 0, len(s.chunks))
+	for _, c := range s.chunks {
+		scores = append(scores, scored{chunk: c, score: cosineSimilarity(query, c.Vector)})
+	}
+
+	sort.Slice(scores, func(i, j int) bool { return scores[i].score > scores[j].score })
+
+	if topK > len(scores) {
+		topK = len(scores)
+	}
+	out := make([]Chunk, topK)
+	for i := 0; i < topK; i++ {
+		out[i] = scores[i].chunk
+	}
+	return out, nil
+}
+
+func (s *MemoryVectorStore) Delete(ctx context.Context, docID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.chunks[:0]
+	for _, c := range s.chunks {
+		if c.DocID != docID {
+			kept = append(kept, c)
+		}
+	}
+	s.chunks = kept
+	return nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return -1
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,89 @@
+package rag
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryVectorStoreSearchRanksByCosineSimilarity(t *testing.T) {
+	s := NewMemoryVectorStore()
+	ctx := context.Background()
+
+	chunks := []Chunk{
+		{ID: "a", DocID: "doc1", Vector: []float32{1, 0}},
+		{ID: "b", DocID: "doc1", Vector: []float32{0, 1}},
+		{ID: "c", DocID: "doc1", Vector: []float32{1, 1}},
+	}
+	if err := s.Add(ctx, chunks); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := s.Search(ctx, []float32{1, 0}, 3)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(got) != 3 || got[0].ID != "a" {
+		t.Fatalf("Search() top result = %+v, want chunk %q first", got, "a")
+	}
+}
+
+func TestMemoryVectorStoreSearchCapsTopKToAvailable(t *testing.T) {
+	s := NewMemoryVectorStore()
+	ctx := context.Background()
+
+	if err := s.Add(ctx, []Chunk{{ID: "a", Vector: []float32{1, 0}}}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := s.Search(ctx, []float32{1, 0}, 5)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Search() returned %d results, want 1 when only 1 chunk is stored", len(got))
+	}
+}
+
+func TestMemoryVectorStoreDeleteRemovesOnlyMatchingDoc(t *testing.T) {
+	s := NewMemoryVectorStore()
+	ctx := context.Background()
+
+	chunks := []Chunk{
+		{ID: "a", DocID: "doc1", Vector: []float32{1, 0}},
+		{ID: "b", DocID: "doc2", Vector: []float32{0, 1}},
+	}
+	if err := s.Add(ctx, chunks); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.Delete(ctx, "doc1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err := s.Search(ctx, []float32{0, 1}, 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "b" {
+		t.Fatalf("Search() after Delete(doc1) = %+v, want only chunk %q", got, "b")
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"identical", []float32{1, 0}, []float32{1, 0}, 1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"opposite", []float32{1, 0}, []float32{-1, 0}, -1},
+		{"mismatched lengths", []float32{1, 0}, []float32{1, 0, 0}, -1},
+		{"empty", []float32{}, []float32{}, -1},
+		{"zero vector", []float32{0, 0}, []float32{1, 0}, 0},
+	}
+	for _, c := range cases {
+		if got := cosineSimilarity(c.a, c.b); got != c.want {
+			t.Errorf("cosineSimilarity(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+//go:build pgvector
+
+package rag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGVectorStore persists chunks in Postgres using the pgvector extension,
+// for corpora too large to hold in memory. Only built with -tags pgvector.
+type PGVectorStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPGVectorStore connects to Postgres and assumes a table has already
+// been created, e.g.:
+//
+//	CREATE TABLE rag_chunks (
+//		id text PRIMARY KEY,
+//		doc_id text NOT NULL,
+//		text text NOT NULL,
+//		embedding vector(1536) NOT NULL
+//	);
+func NewPGVectorStore(ctx context.Context, connString string) (*PGVectorStore, error) {
+	pool, err := pgxpool.New(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("rag: connect to postgres: %w", err)
+	}
+	return &PGVectorStore{pool: pool}, nil
+}
+
+func (s *PGVectorStore) Add(ctx context.Context, chunks []Chunk) error {
+	for _, c := range chunks {
+		_, err := s.pool.Exec(ctx,
+			`INSERT INTO rag_chunks (id, doc_id, text, embedding) VALUES ($1, $2, $3, $4)`,
+			c.ID, c.DocID, c.Text, vectorLiteral(c.Vector))
+		if err != nil {
+			return fmt.Errorf("rag: insert chunk %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *PGVectorStore) Search(ctx context.Context, query []float32, topK int) ([]Chunk, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, doc_id, text FROM rag_chunks ORDER BY embedding <=> $1 LIMIT $2`,
+		vectorLiteral(query), topK)
+	if err != nil {
+		return nil, fmt.Errorf("rag: search: %w", err)
+	}
+	defer rows.Close()
+
+	var chunks []Chunk
+	for rows.Next() {
+		var c Chunk
+		if err := rows.Scan(&c.ID, &c.DocID, &c.Text); err != nil {
+			return nil, fmt.Errorf("rag: scan chunk: %w", err)
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks, rows.Err()
+}
+
+func (s *PGVectorStore) Delete(ctx context.Context, docID string) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM rag_chunks WHERE doc_id = $1`, docID)
+	if err != nil {
+		return fmt.Errorf("rag: delete doc %s: %w", docID, err)
+	}
+	return nil
+}
+
+func vectorLiteral(v []float32) string {
+	s := "["
+	for i, f := range v {
+		if i > 0 {
+			s += ","
+		}
+		s += fmt.Sprintf("%f", f)
+	}
+	return s + "]"
+}
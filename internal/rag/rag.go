@@ -0,0 +1,154 @@
+// Package rag implements retrieval-augmented generation: uploaded
+// documents are chunked, embedded, and stored in a VectorStore so the
+// chat handler can retrieve relevant passages and inject them as context.
+package rag
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrTooManyChunks is returned by AddDocument when a document chunks into
+// more pieces than ChunkConfig.MaxChunks allows.
+var ErrTooManyChunks = errors.New("rag: document exceeds max chunks per upload")
+
+// Chunk is one embedded slice of a document.
+type Chunk struct {
+	ID     string    `json:"id"`
+	DocID  string    `json:"doc_id"`
+	Text   string    `json:"text"`
+	Vector []float32 `json:"-"`
+}
+
+// Document is one uploaded file, split into Chunks.
+type Document struct {
+	Name     string   `json:"name"`
+	ID       string   `json:"id"`
+	ChunkIDs []string `json:"chunk_ids"`
+}
+
+// VectorStore persists embedded chunks and answers nearest-neighbor
+// queries over them. The default implementation is in-memory brute-force
+// cosine similarity; see pgvector_store.go for a Postgres pgvector
+// backend behind the pgvector build tag.
+type VectorStore interface {
+	Add(ctx context.Context, chunks []Chunk) error
+	Search(ctx context.Context, query []float32, topK int) ([]Chunk, error)
+	Delete(ctx context.Context, docID string) error
+}
+
+// Embedder turns text into vectors using a Bedrock embeddings model.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// ChunkConfig controls how uploaded documents are split before embedding.
+type ChunkConfig struct {
+	Size      int // characters per chunk
+	Overlap   int // characters shared between consecutive chunks
+	MaxChunks int // reject a document that would produce more chunks than this; 0 means unbounded
+}
+
+// Store is the RAG subsystem: it owns document metadata and coordinates
+// chunking, embedding, and vector storage for uploads and queries.
+type Store struct {
+	mu       sync.RWMutex
+	docs     map[string]*Document
+	vectors  VectorStore
+	embedder Embedder
+	chunking ChunkConfig
+}
+
+// NewStore builds a Store over the given VectorStore and Embedder.
+func NewStore(vectors VectorStore, embedder Embedder, chunking ChunkConfig) *Store {
+	return &Store{
+		docs:     make(map[string]*Document),
+		vectors:  vectors,
+		embedder: embedder,
+		chunking: chunking,
+	}
+}
+
+// AddDocument chunks and embeds text, storing the result under a new
+// document ID, and returns the new Document (its ID and chunk IDs).
+func (s *Store) AddDocument(ctx context.Context, name, text string) (*Document, error) {
+	texts := ChunkText(text, s.chunking.Size, s.chunking.Overlap)
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("rag: document %q produced no chunks", name)
+	}
+	if s.chunking.MaxChunks > 0 && len(texts) > s.chunking.MaxChunks {
+		return nil, fmt.Errorf("%w: %q produced %d chunks, limit is %d", ErrTooManyChunks, name, len(texts), s.chunking.MaxChunks)
+	}
+
+	vectors, err := s.embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("rag: embed document %q: %w", name, err)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("rag: embedder returned %d vectors for %d chunks", len(vectors), len(texts))
+	}
+
+	docID := newID()
+	doc := &Document{Name: name, ID: docID}
+
+	chunks := make([]Chunk, len(texts))
+	for i, t := range texts {
+		chunkID := newID()
+		doc.ChunkIDs = append(doc.ChunkIDs, chunkID)
+		chunks[i] = Chunk{ID: chunkID, DocID: docID, Text: t, Vector: vectors[i]}
+	}
+
+	if err := s.vectors.Add(ctx, chunks); err != nil {
+		return nil, fmt.Errorf("rag: store chunks for %q: %w", name, err)
+	}
+
+	s.mu.Lock()
+	s.docs[docID] = doc
+	s.mu.Unlock()
+
+	return doc, nil
+}
+
+// ListDocuments returns metadata for every uploaded document.
+func (s *Store) ListDocuments() []*Document {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	docs := make([]*Document, 0, len(s.docs))
+	for _, d := range s.docs {
+		docs = append(docs, d)
+	}
+	return docs
+}
+
+// DeleteDocument removes a document's metadata and its chunks from the
+// vector store.
+func (s *Store) DeleteDocument(ctx context.Context, id string) error {
+	s.mu.Lock()
+	_, ok := s.docs[id]
+	delete(s.docs, id)
+	s.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("rag: document %q not found", id)
+	}
+	return s.vectors.Delete(ctx, id)
+}
+
+// Query embeds the given text and returns the topK most similar chunks.
+func (s *Store) Query(ctx context.Context, text string, topK int) ([]Chunk, error) {
+	vectors, err := s.embedder.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, fmt.Errorf("rag: embed query: %w", err)
+	}
+	return s.vectors.Search(ctx, vectors[0], topK)
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
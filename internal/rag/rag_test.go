@@ -0,0 +1,122 @@
+package rag
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubEmbedder returns a fixed-length zero vector per input text, or err
+// if set, so Store tests don't depend on a real Bedrock call.
+type stubEmbedder struct {
+	dim int
+	err error
+}
+
+func (e *stubEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	vectors := make([][]float32, len(texts))
+	for i := range texts {
+		vectors[i] = make([]float32, e.dim)
+	}
+	return vectors, nil
+}
+
+func newTestStore(chunking ChunkConfig) (*Store, *stubEmbedder) {
+	embedder := &stubEmbedder{dim: 3}
+	return NewStore(NewMemoryVectorStore(), embedder, chunking), embedder
+}
+
+func TestStoreAddDocumentStoresChunksAndMetadata(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	ctx := context.Background()
+
+	doc, err := s.AddDocument(ctx, "doc.txt", "0123456789")
+	if err != nil {
+		t.Fatalf("AddDocument() error = %v", err)
+	}
+	if doc.Name != "doc.txt" {
+		t.Fatalf("AddDocument().Name = %q, want %q", doc.Name, "doc.txt")
+	}
+	if len(doc.ChunkIDs) != 3 {
+		t.Fatalf("AddDocument() produced %d chunk IDs, want 3", len(doc.ChunkIDs))
+	}
+
+	chunks, err := s.Query(ctx, "anything", 10)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("Query() returned %d chunks, want 3", len(chunks))
+	}
+}
+
+func TestStoreAddDocumentEmptyTextIsError(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	if _, err := s.AddDocument(context.Background(), "empty.txt", ""); err == nil {
+		t.Fatalf("AddDocument() error = nil, want non-nil for empty text")
+	}
+}
+
+func TestStoreAddDocumentRejectsTooManyChunks(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0, MaxChunks: 2})
+
+	_, err := s.AddDocument(context.Background(), "big.txt", "0123456789")
+	if !errors.Is(err, ErrTooManyChunks) {
+		t.Fatalf("AddDocument() error = %v, want ErrTooManyChunks", err)
+	}
+}
+
+func TestStoreAddDocumentNoLimitWhenMaxChunksZero(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	if _, err := s.AddDocument(context.Background(), "big.txt", "0123456789"); err != nil {
+		t.Fatalf("AddDocument() error = %v, want nil when MaxChunks is unset", err)
+	}
+}
+
+func TestStoreAddDocumentPropagatesEmbedError(t *testing.T) {
+	s, embedder := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	embedder.err = errors.New("bedrock unavailable")
+
+	if _, err := s.AddDocument(context.Background(), "doc.txt", "0123456789"); err == nil {
+		t.Fatalf("AddDocument() error = nil, want non-nil when the embedder fails")
+	}
+}
+
+func TestStoreListAndDeleteDocument(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	ctx := context.Background()
+
+	doc, err := s.AddDocument(ctx, "doc.txt", "0123456789")
+	if err != nil {
+		t.Fatalf("AddDocument() error = %v", err)
+	}
+
+	if docs := s.ListDocuments(); len(docs) != 1 || docs[0].ID != doc.ID {
+		t.Fatalf("ListDocuments() = %+v, want just %+v", docs, doc)
+	}
+
+	if err := s.DeleteDocument(ctx, doc.ID); err != nil {
+		t.Fatalf("DeleteDocument() error = %v", err)
+	}
+	if docs := s.ListDocuments(); len(docs) != 0 {
+		t.Fatalf("ListDocuments() after delete = %+v, want empty", docs)
+	}
+
+	chunks, err := s.Query(ctx, "anything", 10)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(chunks) != 0 {
+		t.Fatalf("Query() after delete = %+v, want no chunks left to match", chunks)
+	}
+}
+
+func TestStoreDeleteDocumentUnknownIDIsError(t *testing.T) {
+	s, _ := newTestStore(ChunkConfig{Size: 4, Overlap: 0})
+	if err := s.DeleteDocument(context.Background(), "missing"); err == nil {
+		t.Fatalf("DeleteDocument() error = nil, want non-nil for an unknown document")
+	}
+}
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfig reads a router Config from a YAML or JSON file, chosen by the
+// file extension (.yaml/.yml or .json).
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("router: read config %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("router: parse yaml config %s: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("router: parse json config %s: %w", path, err)
+		}
+	default:
+		return cfg, fmt.Errorf("router: unsupported config extension for %s", path)
+	}
+
+	return cfg, nil
+}
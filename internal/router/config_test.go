@@ -0,0 +1,57 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTempConfig(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write temp config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigJSONDurationString(t *testing.T) {
+	path := writeTempConfig(t, "cfg.json", `{"backends":[{"name":"a","model_id":"m","timeout":"30s"}]}`)
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := time.Duration(cfg.Backends[0].Timeout); got != 30*time.Second {
+		t.Fatalf("Timeout = %v, want 30s", got)
+	}
+}
+
+func TestLoadConfigJSONBareNumberTimeoutRejected(t *testing.T) {
+	path := writeTempConfig(t, "cfg.json", `{"backends":[{"name":"a","model_id":"m","timeout":30}]}`)
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("LoadConfig() error = nil, want an error for a bare-number timeout")
+	}
+}
+
+func TestLoadConfigYAMLDurationString(t *testing.T) {
+	path := writeTempConfig(t, "cfg.yaml", "backends:\n  - name: a\n    model_id: m\n    timeout: 30s\n")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := time.Duration(cfg.Backends[0].Timeout); got != 30*time.Second {
+		t.Fatalf("Timeout = %v, want 30s", got)
+	}
+}
+
+func TestLoadConfigYAMLBareNumberTimeoutRejected(t *testing.T) {
+	path := writeTempConfig(t, "cfg.yaml", "backends:\n  - name: a\n    model_id: m\n    timeout: 30\n")
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatal("LoadConfig() error = nil, want an error for a bare-number timeout")
+	}
+}
@@ -0,0 +1,294 @@
+// Package router sits between the /chat handler and Bedrock, spreading
+// requests across multiple model backends with health-aware failover.
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	bedrock "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoHealthyBackend is returned when every configured backend is unhealthy.
+var ErrNoHealthyBackend = errors.New("router: no healthy backend available")
+
+const (
+	// minSamples is the number of requests a backend must have served
+	// before its error rate is used to decide health.
+	minSamples = 5
+	// errorRateThreshold marks a backend unhealthy once its rolling error
+	// rate crosses this fraction.
+	errorRateThreshold = 0.5
+	// cooldown is how long an unhealthy backend is skipped before a single
+	// probe request is allowed through again.
+	cooldown = 30 * time.Second
+	// latencyAlpha is the smoothing factor for the latency EWMA.
+	latencyAlpha = 0.2
+)
+
+// Invoker is the subset of the Bedrock runtime client the router needs,
+// covering both the plain and streaming invoke calls. Satisfied by
+// *bedrockruntime.Client.
+type Invoker interface {
+	InvokeModel(ctx context.Context, input *bedrock.InvokeModelInput, optFns ...func(*bedrock.Options)) (*bedrock.InvokeModelOutput, error)
+	InvokeModelWithResponseStream(ctx context.Context, input *bedrock.InvokeModelWithResponseStreamInput, optFns ...func(*bedrock.Options)) (*bedrock.InvokeModelWithResponseStreamOutput, error)
+}
+
+// Backend is one configured Bedrock model behind the router.
+type Backend struct {
+	Name     string   `json:"name" yaml:"name"`
+	ModelID  string   `json:"model_id" yaml:"model_id"`
+	Priority int      `json:"priority" yaml:"priority"`
+	Weight   int      `json:"weight" yaml:"weight"`
+	Timeout  Duration `json:"timeout" yaml:"timeout"`
+}
+
+// Duration is a time.Duration that must be spelled out with units in
+// config files ("30s", "500ms"). A bare number is rejected rather than
+// silently taken as nanoseconds, which is what encoding/json's default
+// time.Duration handling would otherwise do with something like
+// `"timeout": 30` meant as 30 seconds.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("router: timeout must be a duration string (e.g. \"30s\"), got %s", data)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("router: invalid timeout %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return fmt.Errorf("router: timeout must be a duration string (e.g. \"30s\")")
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("router: invalid timeout %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Config is the top-level shape of the router's YAML/JSON config file.
+type Config struct {
+	Backends []Backend `json:"backends" yaml:"backends"`
+}
+
+// Status summarizes a backend's current health for /router/status.
+type Status struct {
+	Name        string        `json:"name"`
+	ModelID     string        `json:"model_id"`
+	Healthy     bool          `json:"healthy"`
+	ErrorRate   float64       `json:"error_rate"`
+	LatencyEWMA time.Duration `json:"latency_ewma"`
+}
+
+type health struct {
+	mu             sync.Mutex
+	requests       int
+	errors         int
+	latencyEWMA    time.Duration
+	unhealthyUntil time.Time
+	probing        bool
+}
+
+// Router picks a healthy backend for each request and records the outcome
+// so future requests can route around failing models.
+type Router struct {
+	client   Invoker
+	backends []Backend
+	health   map[string]*health
+}
+
+// New builds a Router over the given backends, ordered by priority (lower
+// first) and then weight (higher first).
+func New(client Invoker, cfg Config) *Router {
+	backends := append([]Backend(nil), cfg.Backends...)
+	sort.SliceStable(backends, func(i, j int) bool {
+		if backends[i].Priority != backends[j].Priority {
+			return backends[i].Priority < backends[j].Priority
+		}
+		return backends[i].Weight > backends[j].Weight
+	})
+
+	h := make(map[string]*health, len(backends))
+	for _, b := range backends {
+		h[b.Name] = &health{}
+	}
+
+	return &Router{client: client, backends: backends, health: h}
+}
+
+// Invoke calls the first healthy backend willing to accept the request,
+// falling back to the next one on error. It returns the name of the
+// backend that produced the response.
+func (r *Router) Invoke(ctx context.Context, body []byte) (*bedrock.InvokeModelOutput, string, error) {
+	var lastErr error
+
+	for _, b := range r.candidates() {
+		backendCtx, cancel := ctx, func() {}
+		if b.Timeout > 0 {
+			backendCtx, cancel = context.WithTimeout(ctx, time.Duration(b.Timeout))
+		}
+
+		start := time.Now()
+		out, err := r.client.InvokeModel(backendCtx, &bedrock.InvokeModelInput{
+			Body:        body,
+			ModelId:     strPtr(b.ModelID),
+			ContentType: strPtr("application/json"),
+		})
+		cancel()
+		r.record(b.Name, err, time.Since(start))
+
+		if err == nil {
+			return out, b.Name, nil
+		}
+
+		log.Printf("router: backend %q failed: %v", b.Name, err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = ErrNoHealthyBackend
+	}
+	return nil, "", lastErr
+}
+
+// InvokeStream is the streaming counterpart to Invoke: it opens a response
+// stream against the first healthy backend, failing over to the next one
+// if opening the stream itself errors. Unlike Invoke, no per-backend
+// timeout is applied to the returned stream — its lifetime is the whole
+// SSE session, not a single bounded call — so a slow backend is only
+// detected once it errors outright, not by a timeout cutting it short.
+func (r *Router) InvokeStream(ctx context.Context, body []byte) (*bedrock.InvokeModelWithResponseStreamOutput, string, error) {
+	var lastErr error
+
+	for _, b := range r.candidates() {
+		start := time.Now()
+		out, err := r.client.InvokeModelWithResponseStream(ctx, &bedrock.InvokeModelWithResponseStreamInput{
+			Body:        body,
+			ModelId:     strPtr(b.ModelID),
+			ContentType: strPtr("application/json"),
+		})
+		r.record(b.Name, err, time.Since(start))
+
+		if err == nil {
+			return out, b.Name, nil
+		}
+
+		log.Printf("router: backend %q failed to open stream: %v", b.Name, err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = ErrNoHealthyBackend
+	}
+	return nil, "", lastErr
+}
+
+// candidates returns the backends eligible for this request: healthy ones
+// in priority order, plus at most one unhealthy backend being probed.
+func (r *Router) candidates() []Backend {
+	var eligible []Backend
+	for _, b := range r.backends {
+		h := r.health[b.Name]
+		if r.tryReserveProbe(h) {
+			eligible = append(eligible, b)
+		}
+	}
+	return eligible
+}
+
+// tryReserveProbe implements the half-open step of the circuit breaker: a
+// healthy backend is always eligible, a backend still within its cooldown
+// window is never eligible, and once the cooldown has elapsed exactly one
+// caller reserves the single probe slot until record() frees it again.
+func (r *Router) tryReserveProbe(h *health) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.unhealthyUntil.IsZero() {
+		return true
+	}
+	if time.Now().Before(h.unhealthyUntil) {
+		return false
+	}
+	if h.probing {
+		return false
+	}
+	h.probing = true
+	return true
+}
+
+func (r *Router) record(name string, err error, latency time.Duration) {
+	h, ok := r.health[name]
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requests++
+	if err != nil {
+		h.errors++
+	}
+	if h.latencyEWMA == 0 {
+		h.latencyEWMA = latency
+	} else {
+		h.latencyEWMA = time.Duration(latencyAlpha*float64(latency) + (1-latencyAlpha)*float64(h.latencyEWMA))
+	}
+
+	// probing is freed unconditionally: whether this call was an ordinary
+	// request or a half-open probe, its outcome is now reflected in
+	// unhealthyUntil below, so the next candidates() call should decide
+	// fresh rather than stay latched onto a stale in-flight probe.
+	h.probing = false
+
+	errRate := float64(h.errors) / float64(h.requests)
+	switch {
+	case err != nil && h.requests >= minSamples && errRate >= errorRateThreshold:
+		h.unhealthyUntil = time.Now().Add(cooldown)
+	case err == nil:
+		h.unhealthyUntil = time.Time{}
+		h.requests, h.errors = 1, 0
+	}
+}
+
+// Status returns the current health snapshot for every configured backend.
+func (r *Router) Status() []Status {
+	statuses := make([]Status, 0, len(r.backends))
+	for _, b := range r.backends {
+		h := r.health[b.Name]
+		h.mu.Lock()
+		var errRate float64
+		if h.requests > 0 {
+			errRate = float64(h.errors) / float64(h.requests)
+		}
+		statuses = append(statuses, Status{
+			Name:        b.Name,
+			ModelID:     b.ModelID,
+			Healthy:     h.unhealthyUntil.IsZero() || time.Now().After(h.unhealthyUntil),
+			ErrorRate:   errRate,
+			LatencyEWMA: h.latencyEWMA,
+		})
+		h.mu.Unlock()
+	}
+	return statuses
+}
+
+func strPtr(s string) *string { return &s }
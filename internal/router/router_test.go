@@ -0,0 +1,139 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bedrock "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+// fakeInvoker lets tests script per-backend success/failure without a real
+// Bedrock client.
+type fakeInvoker struct {
+	fail map[string]bool
+}
+
+func (f *fakeInvoker) InvokeModel(ctx context.Context, input *bedrock.InvokeModelInput, optFns ...func(*bedrock.Options)) (*bedrock.InvokeModelOutput, error) {
+	if f.fail[*input.ModelId] {
+		return nil, errors.New("backend failure")
+	}
+	return &bedrock.InvokeModelOutput{}, nil
+}
+
+func (f *fakeInvoker) InvokeModelWithResponseStream(ctx context.Context, input *bedrock.InvokeModelWithResponseStreamInput, optFns ...func(*bedrock.Options)) (*bedrock.InvokeModelWithResponseStreamOutput, error) {
+	if f.fail[*input.ModelId] {
+		return nil, errors.New("backend failure")
+	}
+	return &bedrock.InvokeModelWithResponseStreamOutput{}, nil
+}
+
+func newTestRouter(inv *fakeInvoker, backends ...Backend) *Router {
+	return New(inv, Config{Backends: backends})
+}
+
+func TestInvokeFailsOverToNextBackend(t *testing.T) {
+	inv := &fakeInvoker{fail: map[string]bool{"primary": true}}
+	r := newTestRouter(inv,
+		Backend{Name: "a", ModelID: "primary", Priority: 0},
+		Backend{Name: "b", ModelID: "secondary", Priority: 1},
+	)
+
+	_, name, err := r.Invoke(context.Background(), []byte("{}"))
+	if err != nil {
+		t.Fatalf("Invoke() error = %v, want nil", err)
+	}
+	if name != "b" {
+		t.Fatalf("Invoke() backend = %q, want %q", name, "b")
+	}
+}
+
+func TestInvokeReturnsErrorWhenAllBackendsFail(t *testing.T) {
+	inv := &fakeInvoker{fail: map[string]bool{"primary": true, "secondary": true}}
+	r := newTestRouter(inv,
+		Backend{Name: "a", ModelID: "primary"},
+		Backend{Name: "b", ModelID: "secondary"},
+	)
+
+	_, _, err := r.Invoke(context.Background(), []byte("{}"))
+	if err == nil {
+		t.Fatal("Invoke() error = nil, want an error")
+	}
+}
+
+func TestBackendMarkedUnhealthyAfterErrorRateCrossesThreshold(t *testing.T) {
+	inv := &fakeInvoker{fail: map[string]bool{"primary": true}}
+	r := newTestRouter(inv,
+		Backend{Name: "a", ModelID: "primary"},
+		Backend{Name: "b", ModelID: "secondary"},
+	)
+
+	// Below minSamples, a failing backend is still "healthy" and stays in
+	// the candidate set (it's just losing to the fallback on each call).
+	for i := 0; i < minSamples-1; i++ {
+		if _, _, err := r.Invoke(context.Background(), []byte("{}")); err != nil {
+			t.Fatalf("Invoke() error = %v, want nil", err)
+		}
+	}
+	statuses := r.Status()
+	if !statuses[0].Healthy {
+		t.Fatalf("backend %q marked unhealthy before minSamples reached", statuses[0].Name)
+	}
+
+	// One more failure crosses minSamples with a 100%% error rate.
+	if _, _, err := r.Invoke(context.Background(), []byte("{}")); err != nil {
+		t.Fatalf("Invoke() error = %v, want nil", err)
+	}
+	statuses = r.Status()
+	if statuses[0].Healthy {
+		t.Fatalf("backend %q still healthy after crossing errorRateThreshold", statuses[0].Name)
+	}
+}
+
+func TestUnhealthyBackendExcludedUntilCooldownThenProbed(t *testing.T) {
+	inv := &fakeInvoker{fail: map[string]bool{"primary": true}}
+	r := newTestRouter(inv, Backend{Name: "a", ModelID: "primary"})
+
+	for i := 0; i < minSamples; i++ {
+		r.Invoke(context.Background(), []byte("{}"))
+	}
+	if len(r.candidates()) != 0 {
+		t.Fatal("unhealthy backend still in candidates() before cooldown expires")
+	}
+
+	// Force the cooldown to have already elapsed.
+	r.health["a"].mu.Lock()
+	r.health["a"].unhealthyUntil = time.Now().Add(-time.Second)
+	r.health["a"].mu.Unlock()
+
+	cands := r.candidates()
+	if len(cands) != 1 {
+		t.Fatalf("candidates() after cooldown = %d backends, want 1 probe slot", len(cands))
+	}
+
+	// A second concurrent probe attempt should not also be let through.
+	r.health["a"].mu.Lock()
+	r.health["a"].unhealthyUntil = time.Now().Add(-time.Second)
+	r.health["a"].mu.Unlock()
+	if r.tryReserveProbe(r.health["a"]) {
+		t.Fatal("tryReserveProbe() granted a second concurrent probe slot")
+	}
+}
+
+func TestSuccessfulInvokeResetsHealth(t *testing.T) {
+	inv := &fakeInvoker{fail: map[string]bool{}}
+	r := newTestRouter(inv, Backend{Name: "a", ModelID: "primary"})
+
+	r.record("a", errors.New("boom"), time.Millisecond)
+	r.record("a", errors.New("boom"), time.Millisecond)
+
+	if _, _, err := r.Invoke(context.Background(), []byte("{}")); err != nil {
+		t.Fatalf("Invoke() error = %v, want nil", err)
+	}
+
+	statuses := r.Status()
+	if !statuses[0].Healthy || statuses[0].ErrorRate != 0 {
+		t.Fatalf("health not reset after success: %+v", statuses[0])
+	}
+}
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var sessionsBucket = []byte("sessions")
+
+// BoltStore persists sessions to a local BoltDB file, so history survives
+// process restarts on a single node.
+type BoltStore struct {
+	db     *bolt.DB
+	limits Limits
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB database at path.
+func NewBoltStore(path string, limits Limits) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("session: open bolt db %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("session: create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db, limits: limits}, nil
+}
+
+func (s *BoltStore) Append(ctx context.Context, id string, msgs ...Message) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(sessionsBucket)
+
+		var existing []Message
+		if raw := b.Get([]byte(id)); raw != nil {
+			if err := json.Unmarshal(raw, &existing); err != nil {
+				return fmt.Errorf("session: decode %s: %w", id, err)
+			}
+		}
+
+		updated := trim(append(existing, msgs...), s.limits)
+		raw, err := json.Marshal(updated)
+		if err != nil {
+			return fmt.Errorf("session: encode %s: %w", id, err)
+		}
+		return b.Put([]byte(id), raw)
+	})
+}
+
+func (s *BoltStore) Get(ctx context.Context, id string) ([]Message, error) {
+	var msgs []Message
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(sessionsBucket).Get([]byte(id))
+		if raw == nil {
+			return nil
+		}
+		return json.Unmarshal(raw, &msgs)
+	})
+	return msgs, err
+}
+
+func (s *BoltStore) Delete(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).Delete([]byte(id))
+	})
+}
+
+func (s *BoltStore) List(ctx context.Context) ([]string, error) {
+	var ids []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).ForEach(func(k, _ []byte) error {
+			ids = append(ids, string(k))
+			return nil
+		})
+	})
+	return ids, err
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func newTestBoltStore(t *testing.T, limits Limits) *BoltStore {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sessions.db")
+	s, err := NewBoltStore(path, limits)
+	if err != nil {
+		t.Fatalf("NewBoltStore() error = %v", err)
+	}
+	return s
+}
+
+func TestBoltStoreAppendGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newTestBoltStore(t, Limits{})
+
+	if err := s.Append(ctx, "a", Message{Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := s.Append(ctx, "a", Message{Role: "assistant", Content: "hello"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	msgs, err := s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "hi" || msgs[1].Content != "hello" {
+		t.Fatalf("Get() = %+v, want [hi, hello] in order", msgs)
+	}
+
+	if err := s.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	msgs, err = s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() after delete error = %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("Get() after delete = %+v, want empty", msgs)
+	}
+}
+
+func TestBoltStoreAppendTrimsToMaxMessages(t *testing.T) {
+	s := newTestBoltStore(t, Limits{MaxMessages: 2})
+	ctx := context.Background()
+
+	for _, content := range []string{"one", "two", "three"} {
+		if err := s.Append(ctx, "a", Message{Role: "user", Content: content}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	msgs, err := s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "two" || msgs[1].Content != "three" {
+		t.Fatalf("Get() = %+v, want the last 2 messages [two, three]", msgs)
+	}
+}
+
+func TestBoltStoreListReturnsAllIDs(t *testing.T) {
+	s := newTestBoltStore(t, Limits{})
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := s.Append(ctx, id, Message{Role: "user", Content: "hi"}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	ids, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("List() = %v, want 3 ids", ids)
+	}
+}
+
+func TestBoltStoreGetUnknownSessionIsEmptyNotError(t *testing.T) {
+	s := newTestBoltStore(t, Limits{})
+	msgs, err := s.Get(context.Background(), "never-appended")
+	if err != nil {
+		t.Fatalf("Get() error = %v, want nil", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("Get() = %+v, want empty", msgs)
+	}
+}
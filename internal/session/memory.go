@@ -0,0 +1,53 @@
+package session
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store. History is lost on restart and is not
+// shared across processes.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string][]Message
+	limits   Limits
+}
+
+// NewMemoryStore builds an empty MemoryStore enforcing the given limits.
+func NewMemoryStore(limits Limits) *MemoryStore {
+	return &MemoryStore{sessions: make(map[string][]Message), limits: limits}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, id string, msgs ...Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[id] = trim(append(s.sessions[id], msgs...), s.limits)
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string) ([]Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Message, len(s.sessions[id]))
+	copy(out, s.sessions[id])
+	return out, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
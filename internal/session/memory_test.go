@@ -0,0 +1,114 @@
+package session
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreAppendGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(Limits{})
+
+	if err := s.Append(ctx, "a", Message{Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := s.Append(ctx, "a", Message{Role: "assistant", Content: "hello"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	msgs, err := s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "hi" || msgs[1].Content != "hello" {
+		t.Fatalf("Get() = %+v, want [hi, hello] in order", msgs)
+	}
+
+	if err := s.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	msgs, err = s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() after delete error = %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("Get() after delete = %+v, want empty", msgs)
+	}
+}
+
+func TestMemoryStoreGetUnknownSessionIsEmptyNotError(t *testing.T) {
+	s := NewMemoryStore(Limits{})
+	msgs, err := s.Get(context.Background(), "never-appended")
+	if err != nil {
+		t.Fatalf("Get() error = %v, want nil", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("Get() = %+v, want empty", msgs)
+	}
+}
+
+func TestMemoryStoreAppendTrimsToMaxMessages(t *testing.T) {
+	s := NewMemoryStore(Limits{MaxMessages: 2})
+	ctx := context.Background()
+
+	for _, content := range []string{"one", "two", "three"} {
+		if err := s.Append(ctx, "a", Message{Role: "user", Content: content}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	msgs, err := s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "two" || msgs[1].Content != "three" {
+		t.Fatalf("Get() = %+v, want the last 2 messages [two, three]", msgs)
+	}
+}
+
+func TestMemoryStoreAppendTrimsToMaxTokens(t *testing.T) {
+	s := NewMemoryStore(Limits{MaxTokens: 5})
+	ctx := context.Background()
+
+	// approxTokens counts len(content)/4 + 1 per message, so each of these
+	// 12-char messages costs 4 tokens; three of them (12) exceeds the
+	// 5-token budget and forces the oldest ones out.
+	for _, content := range []string{"111111111111", "222222222222", "333333333333"} {
+		if err := s.Append(ctx, "a", Message{Role: "user", Content: content}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	msgs, err := s.Get(ctx, "a")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Content != "333333333333" {
+		t.Fatalf("Get() = %+v, want only the most recent message", msgs)
+	}
+}
+
+func TestMemoryStoreListReturnsSortedIDs(t *testing.T) {
+	s := NewMemoryStore(Limits{})
+	ctx := context.Background()
+
+	for _, id := range []string{"c", "a", "b"} {
+		if err := s.Append(ctx, id, Message{Role: "user", Content: "hi"}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	ids, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(ids) != len(want) {
+		t.Fatalf("List() = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("List() = %v, want %v", ids, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore persists sessions in Redis, so conversations survive restarts
+// and are shared across every process behind a load balancer.
+type RedisStore struct {
+	client *redis.Client
+	limits Limits
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore against an already-configured client.
+// Keys are stored under "session:<id>".
+func NewRedisStore(client *redis.Client, limits Limits) *RedisStore {
+	return &RedisStore{client: client, limits: limits, prefix: "session:"}
+}
+
+func (s *RedisStore) key(id string) string { return s.prefix + id }
+
+// Append is a read-modify-write under a WATCH on the session key, so two
+// concurrent appends to the same session (e.g. from different processes
+// behind a load balancer) can't race and silently drop one of them; the
+// loser's transaction is rejected and retried against the fresh value.
+func (s *RedisStore) Append(ctx context.Context, id string, msgs ...Message) error {
+	key := s.key(id)
+
+	txf := func(tx *redis.Tx) error {
+		raw, err := tx.Get(ctx, key).Bytes()
+		if err != nil && err != redis.Nil {
+			return fmt.Errorf("session: read %s: %w", id, err)
+		}
+
+		var existing []Message
+		if err == nil {
+			if err := json.Unmarshal(raw, &existing); err != nil {
+				return fmt.Errorf("session: decode %s: %w", id, err)
+			}
+		}
+
+		updated := trim(append(existing, msgs...), s.limits)
+		encoded, err := json.Marshal(updated)
+		if err != nil {
+			return fmt.Errorf("session: encode %s: %w", id, err)
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, encoded, 0)
+			return nil
+		})
+		return err
+	}
+
+	const maxAttempts = 5
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err := s.client.Watch(ctx, txf, key)
+		if err == nil {
+			return nil
+		}
+		if err == redis.TxFailedErr {
+			continue
+		}
+		return fmt.Errorf("session: append %s: %w", id, err)
+	}
+	return fmt.Errorf("session: append %s: too many concurrent writers", id)
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) ([]Message, error) {
+	raw, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("session: read %s: %w", id, err)
+	}
+
+	var msgs []Message
+	if err := json.Unmarshal(raw, &msgs); err != nil {
+		return nil, fmt.Errorf("session: decode %s: %w", id, err)
+	}
+	return msgs, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	return s.client.Del(ctx, s.key(id)).Err()
+}
+
+func (s *RedisStore) List(ctx context.Context) ([]string, error) {
+	var ids []string
+	iter := s.client.Scan(ctx, 0, s.prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		ids = append(ids, iter.Val()[len(s.prefix):])
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("session: scan: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestRedisStore connects to a local Redis instance and skips the test
+// if one isn't reachable; these tests exercise the real WATCH/MULTI path
+// in Append, which a mock client can't meaningfully stand in for.
+func newTestRedisStore(t *testing.T, limits Limits) (*RedisStore, string) {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6379"})
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("no redis reachable at 127.0.0.1:6379, skipping: %v", err)
+	}
+
+	id := "test-" + newSessionID()
+	t.Cleanup(func() {
+		client.Del(context.Background(), "session:"+id)
+		client.Close()
+	})
+	return NewRedisStore(client, limits), id
+}
+
+func TestRedisStoreAppendGetDelete(t *testing.T) {
+	s, id := newTestRedisStore(t, Limits{})
+	ctx := context.Background()
+
+	if err := s.Append(ctx, id, Message{Role: "user", Content: "hi"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := s.Append(ctx, id, Message{Role: "assistant", Content: "hello"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	msgs, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].Content != "hi" || msgs[1].Content != "hello" {
+		t.Fatalf("Get() = %+v, want [hi, hello] in order", msgs)
+	}
+
+	if err := s.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	msgs, err = s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() after delete error = %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Fatalf("Get() after delete = %+v, want empty", msgs)
+	}
+}
+
+// TestRedisStoreAppendSurvivesConcurrentWriters drives many goroutines
+// appending to the same session at once. Append's WATCH/MULTI retry loop
+// exists precisely to stop one writer's update from clobbering another's;
+// without it, concurrent read-modify-writes would drop messages.
+func TestRedisStoreAppendSurvivesConcurrentWriters(t *testing.T) {
+	s, id := newTestRedisStore(t, Limits{})
+	ctx := context.Background()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+
+	var start sync.WaitGroup
+	start.Add(1)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			start.Wait()
+			errs <- s.Append(ctx, id, Message{Role: "user", Content: "msg"})
+			_ = n
+		}(i)
+	}
+	start.Done()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	msgs, err := s.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(msgs) != writers {
+		t.Fatalf("Get() returned %d messages, want %d (no writer should be dropped)", len(msgs), writers)
+	}
+}
@@ -0,0 +1,56 @@
+// Package session provides per-session conversation storage behind a
+// pluggable Store interface, so a single process can serve many
+// independent conversations instead of one shared global history.
+package session
+
+import "context"
+
+// Message is one turn of a conversation.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Limits bounds how much history a session retains. Zero means unbounded.
+type Limits struct {
+	MaxMessages int
+	MaxTokens   int
+}
+
+// Store persists conversation history keyed by an opaque session ID.
+type Store interface {
+	// Append adds messages to the session, trimming the oldest turns if
+	// the configured limits are exceeded.
+	Append(ctx context.Context, id string, msgs ...Message) error
+	// Get returns the current history for a session, oldest first.
+	Get(ctx context.Context, id string) ([]Message, error)
+	// Delete clears a session's history entirely.
+	Delete(ctx context.Context, id string) error
+	// List returns the IDs of all known sessions.
+	List(ctx context.Context) ([]string, error)
+}
+
+// approxTokens gives a cheap token estimate without a real tokenizer,
+// good enough for enforcing a soft max-token window.
+func approxTokens(msgs []Message) int {
+	n := 0
+	for _, m := range msgs {
+		n += len(m.Content)/4 + 1
+	}
+	return n
+}
+
+// trim drops the oldest messages until both limits are satisfied.
+func trim(msgs []Message, lim Limits) []Message {
+	if lim.MaxMessages > 0 {
+		for len(msgs) > lim.MaxMessages {
+			msgs = msgs[1:]
+		}
+	}
+	if lim.MaxTokens > 0 {
+		for len(msgs) > 0 && approxTokens(msgs) > lim.MaxTokens {
+			msgs = msgs[1:]
+		}
+	}
+	return msgs
+}
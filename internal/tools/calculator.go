@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CalculatorTool evaluates basic arithmetic expressions (+ - * / and
+// parentheses) so the model doesn't have to do mental math.
+type CalculatorTool struct{}
+
+func NewCalculatorTool() *CalculatorTool { return &CalculatorTool{} }
+
+func (t *CalculatorTool) Name() string { return "calculator" }
+
+func (t *CalculatorTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"name": "calculator",
+		"description": "Evaluate a basic arithmetic expression with +, -, *, /, and parentheses.",
+		"input_schema": {
+			"type": "object",
+			"properties": {
+				"expression": {"type": "string", "description": "e.g. \"(2 + 3) * 4\""}
+			},
+			"required": ["expression"]
+		}
+	}`)
+}
+
+func (t *CalculatorTool) Invoke(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var params struct {
+		Expression string `json:"expression"`
+	}
+	if err := json.Unmarshal(input, &params); err != nil {
+		return nil, fmt.Errorf("calculator: bad input: %w", err)
+	}
+
+	result, err := evalExpression(params.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("calculator: %w", err)
+	}
+
+	return json.Marshal(map[string]float64{"result": result})
+}
+
+// evalExpression parses and evaluates a simple arithmetic expression with
+// standard operator precedence using recursive descent.
+func evalExpression(expr string) (float64, error) {
+	p := &exprParser{input: []rune(strings.TrimSpace(expr))}
+	val, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return 0, fmt.Errorf("unexpected input at position %d", p.pos)
+	}
+	return val, nil
+}
+
+type exprParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *exprParser) peek() rune {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *exprParser) parseExpr() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '+':
+			p.pos++
+			right, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			left += right
+		case '-':
+			p.pos++
+			right, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			left -= right
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *exprParser) parseTerm() (float64, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch p.peek() {
+		case '*':
+			p.pos++
+			right, err := p.parseFactor()
+			if err != nil {
+				return 0, err
+			}
+			left *= right
+		case '/':
+			p.pos++
+			right, err := p.parseFactor()
+			if err != nil {
+				return 0, err
+			}
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			left /= right
+		default:
+			return left, nil
+		}
+	}
+}
+
+func (p *exprParser) parseFactor() (float64, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		val, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("expected closing paren")
+		}
+		p.pos++
+		return val, nil
+	case c == '-':
+		p.pos++
+		val, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+		return -val, nil
+	default:
+		return p.parseNumber()
+	}
+}
+
+func (p *exprParser) parseNumber() (float64, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, fmt.Errorf("expected number at position %d", p.pos)
+	}
+	return strconv.ParseFloat(string(p.input[start:p.pos]), 64)
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestEvalExpression(t *testing.T) {
+	cases := []struct {
+		expr string
+		want float64
+	}{
+		{"2 + 3", 5},
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"10 / 2 / 5", 1},
+		{"-5 + 3", -2},
+		{"-(2 + 3)", -5},
+		{"3.5 * 2", 7},
+	}
+	for _, c := range cases {
+		got, err := evalExpression(c.expr)
+		if err != nil {
+			t.Fatalf("evalExpression(%q) error = %v", c.expr, err)
+		}
+		if got != c.want {
+			t.Errorf("evalExpression(%q) = %v, want %v", c.expr, got, c.want)
+		}
+	}
+}
+
+func TestEvalExpressionErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"1 / 0",
+		"1 $ 2",
+		"1 2",
+	}
+	for _, expr := range cases {
+		if _, err := evalExpression(expr); err == nil {
+			t.Errorf("evalExpression(%q) error = nil, want non-nil", expr)
+		}
+	}
+}
+
+func TestCalculatorToolInvoke(t *testing.T) {
+	tool := NewCalculatorTool()
+	input, _ := json.Marshal(map[string]string{"expression": "2 + 2"})
+
+	out, err := tool.Invoke(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+
+	var parsed struct {
+		Result float64 `json:"result"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("Invoke() result unmarshal error = %v", err)
+	}
+	if parsed.Result != 4 {
+		t.Fatalf("Invoke() result = %v, want 4", parsed.Result)
+	}
+}
+
+func TestCalculatorToolInvokeBadInput(t *testing.T) {
+	tool := NewCalculatorTool()
+	if _, err := tool.Invoke(context.Background(), json.RawMessage(`not json`)); err == nil {
+		t.Fatalf("Invoke() error = nil, want non-nil for malformed input")
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FetchTool lets the model retrieve a URL's body, restricted to an
+// allowlist of hosts so it can't be used to probe arbitrary internal or
+// external services.
+type FetchTool struct {
+	allowedHosts map[string]bool
+	client       *http.Client
+}
+
+// NewFetchTool builds a FetchTool that will only fetch from the given
+// hosts (e.g. "example.com"). Redirects are re-checked against the same
+// allowlist on every hop, so an allowlisted host can't be used to bounce
+// the request to somewhere else via a 3xx response.
+func NewFetchTool(allowedHosts []string) *FetchTool {
+	allowed := make(map[string]bool, len(allowedHosts))
+	for _, h := range allowedHosts {
+		allowed[h] = true
+	}
+	t := &FetchTool{allowedHosts: allowed}
+	t.client = &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" && req.URL.Scheme != "http" {
+				return fmt.Errorf("fetch: unsupported redirect scheme %q", req.URL.Scheme)
+			}
+			if !t.allowedHosts[req.URL.Hostname()] {
+				return fmt.Errorf("fetch: redirect to non-allowlisted host %q", req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+	return t
+}
+
+func (t *FetchTool) Name() string { return "http_fetch" }
+
+func (t *FetchTool) Schema() json.RawMessage {
+	return json.RawMessage(`{
+		"name": "http_fetch",
+		"description": "Fetch the text body of an allowlisted URL over HTTP GET.",
+		"input_schema": {
+			"type": "object",
+			"properties": {
+				"url": {"type": "string", "description": "The URL to fetch"}
+			},
+			"required": ["url"]
+		}
+	}`)
+}
+
+func (t *FetchTool) Invoke(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var params struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(input, &params); err != nil {
+		return nil, fmt.Errorf("fetch: bad input: %w", err)
+	}
+
+	u, err := url.Parse(params.URL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: invalid url: %w", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("fetch: unsupported scheme %q", u.Scheme)
+	}
+	if !t.allowedHosts[u.Hostname()] {
+		return nil, fmt.Errorf("fetch: host %q is not allowlisted", u.Hostname())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: build request: %w", err)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("fetch: read body: %w", err)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"status": resp.StatusCode,
+		"body":   string(body),
+	})
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// newServerOn starts an httptest.Server bound to a specific loopback
+// address rather than httptest's default 127.0.0.1, so two servers in the
+// same test have distinct hostnames for the allowlist to distinguish.
+func newServerOn(t *testing.T, addr string, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	l, err := net.Listen("tcp", addr+":0")
+	if err != nil {
+		t.Skipf("cannot listen on %s, skipping: %v", addr, err)
+	}
+	srv := httptest.NewUnstartedServer(handler)
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func invokeFetch(t *testing.T, tool *FetchTool, rawURL string) (status int, body string, err error) {
+	t.Helper()
+	input, _ := json.Marshal(map[string]string{"url": rawURL})
+	out, err := tool.Invoke(context.Background(), input)
+	if err != nil {
+		return 0, "", err
+	}
+	var parsed struct {
+		Status int    `json:"status"`
+		Body   string `json:"body"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("Invoke() result unmarshal error = %v", err)
+	}
+	return parsed.Status, parsed.Body, nil
+}
+
+func TestFetchToolRejectsNonAllowlistedHost(t *testing.T) {
+	tool := NewFetchTool([]string{"example.com"})
+	if _, _, err := invokeFetch(t, tool, "https://evil.example/"); err == nil {
+		t.Fatalf("Invoke() error = nil, want non-nil for a non-allowlisted host")
+	}
+}
+
+func TestFetchToolRejectsNonHTTPScheme(t *testing.T) {
+	tool := NewFetchTool([]string{"example.com"})
+	if _, _, err := invokeFetch(t, tool, "file:///etc/passwd"); err == nil {
+		t.Fatalf("Invoke() error = nil, want non-nil for a file:// URL")
+	}
+}
+
+func TestFetchToolAllowsAllowlistedHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	host := mustHost(t, srv.URL)
+	tool := NewFetchTool([]string{host})
+
+	status, body, err := invokeFetch(t, tool, srv.URL)
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if status != http.StatusOK || body != "hello" {
+		t.Fatalf("Invoke() = (%d, %q), want (200, \"hello\")", status, body)
+	}
+}
+
+// TestFetchToolRejectsRedirectToNonAllowlistedHost confirms the allowlist
+// is re-checked on every redirect hop, not just the initial request.
+func TestFetchToolRejectsRedirectToNonAllowlistedHost(t *testing.T) {
+	evil := newServerOn(t, "127.0.0.2", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("should never be reached"))
+	})
+
+	bouncer := newServerOn(t, "127.0.0.1", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, evil.URL, http.StatusFound)
+	})
+
+	// Only the bouncer's host is allowlisted; the redirect target isn't.
+	tool := NewFetchTool([]string{mustHost(t, bouncer.URL)})
+
+	if _, _, err := invokeFetch(t, tool, bouncer.URL); err == nil {
+		t.Fatalf("Invoke() error = nil, want non-nil for a redirect off the allowlist")
+	}
+}
+
+func TestFetchToolAllowsRedirectBetweenAllowlistedHosts(t *testing.T) {
+	target := newServerOn(t, "127.0.0.2", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("landed"))
+	})
+
+	bouncer := newServerOn(t, "127.0.0.1", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	})
+
+	tool := NewFetchTool([]string{mustHost(t, bouncer.URL), mustHost(t, target.URL)})
+
+	status, body, err := invokeFetch(t, tool, bouncer.URL)
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if status != http.StatusOK || body != "landed" {
+		t.Fatalf("Invoke() = (%d, %q), want (200, \"landed\")", status, body)
+	}
+}
+
+func mustHost(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) error = %v", rawURL, err)
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,82 @@
+// Package tools implements Anthropic's tool-use protocol: a registerable
+// set of local functions the model can ask the chat handler to invoke
+// mid-conversation.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Tool is a single function the model can call. Schema returns the full
+// tool definition (name, description, input_schema) ready to drop into
+// the Bedrock request's "tools" array.
+type Tool interface {
+	Name() string
+	Schema() json.RawMessage
+	Invoke(ctx context.Context, input json.RawMessage) (json.RawMessage, error)
+}
+
+// Registry holds the tools available to the model for a given server.
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+}
+
+// NewRegistry builds an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]Tool)}
+}
+
+// Register adds a tool, replacing any existing tool with the same name.
+func (r *Registry) Register(t Tool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[t.Name()] = t
+}
+
+// Get looks up a tool by name.
+func (r *Registry) Get(name string) (Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tools[name]
+	return t, ok
+}
+
+// Specs returns every registered tool's schema, in the shape Bedrock
+// expects for the request's "tools" array. Returns nil if no tools are
+// registered so callers can omit the field entirely.
+func (r *Registry) Specs() []json.RawMessage {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.tools) == 0 {
+		return nil
+	}
+	specs := make([]json.RawMessage, 0, len(r.tools))
+	for _, t := range r.tools {
+		specs = append(specs, t.Schema())
+	}
+	return specs
+}
+
+// Invoke looks up and runs a tool by name, returning an error result
+// instead of failing the caller when the tool is unknown or errors out -
+// the model should see that its tool call failed and can retry or explain.
+func (r *Registry) Invoke(ctx context.Context, name string, input json.RawMessage) json.RawMessage {
+	t, ok := r.Get(name)
+	if !ok {
+		return errorResult(fmt.Errorf("unknown tool %q", name))
+	}
+	out, err := t.Invoke(ctx, input)
+	if err != nil {
+		return errorResult(err)
+	}
+	return out
+}
+
+func errorResult(err error) json.RawMessage {
+	b, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return b
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type stubTool struct {
+	name   string
+	output json.RawMessage
+	err    error
+}
+
+func (s stubTool) Name() string            { return s.name }
+func (s stubTool) Schema() json.RawMessage { return json.RawMessage(`{"name":"` + s.name + `"}`) }
+func (s stubTool) Invoke(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	return s.output, s.err
+}
+
+func TestRegistryGetReturnsRegisteredTool(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "foo"})
+
+	got, ok := r.Get("foo")
+	if !ok {
+		t.Fatalf("Get(%q) ok = false, want true", "foo")
+	}
+	if got.Name() != "foo" {
+		t.Fatalf("Get(%q).Name() = %q, want foo", "foo", got.Name())
+	}
+
+	if _, ok := r.Get("missing"); ok {
+		t.Fatalf("Get(%q) ok = true, want false", "missing")
+	}
+}
+
+func TestRegistryRegisterReplacesSameName(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "foo", output: json.RawMessage(`1`)})
+	r.Register(stubTool{name: "foo", output: json.RawMessage(`2`)})
+
+	got, _ := r.Get("foo")
+	out, err := got.Invoke(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if string(out) != "2" {
+		t.Fatalf("Invoke() = %s, want the second registration's output", out)
+	}
+}
+
+func TestRegistrySpecsEmptyIsNil(t *testing.T) {
+	r := NewRegistry()
+	if specs := r.Specs(); specs != nil {
+		t.Fatalf("Specs() = %v, want nil for an empty registry", specs)
+	}
+}
+
+func TestRegistrySpecsReturnsOneSchemaPerTool(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "foo"})
+	r.Register(stubTool{name: "bar"})
+
+	specs := r.Specs()
+	if len(specs) != 2 {
+		t.Fatalf("Specs() returned %d schemas, want 2", len(specs))
+	}
+}
+
+func TestRegistryInvokeUnknownToolReturnsErrorResult(t *testing.T) {
+	r := NewRegistry()
+	out := r.Invoke(context.Background(), "missing", nil)
+
+	var parsed struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("Invoke() result unmarshal error = %v", err)
+	}
+	if parsed.Error == "" {
+		t.Fatalf("Invoke() result = %s, want a non-empty error field", out)
+	}
+}
+
+func TestRegistryInvokeToolErrorReturnsErrorResult(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "foo", err: context.DeadlineExceeded})
+
+	out := r.Invoke(context.Background(), "foo", nil)
+	var parsed struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("Invoke() result unmarshal error = %v", err)
+	}
+	if parsed.Error == "" {
+		t.Fatalf("Invoke() result = %s, want a non-empty error field", out)
+	}
+}
+
+func TestRegistryInvokeSuccessReturnsToolOutput(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubTool{name: "foo", output: json.RawMessage(`{"ok":true}`)})
+
+	out := r.Invoke(context.Background(), "foo", nil)
+	if string(out) != `{"ok":true}` {
+		t.Fatalf("Invoke() = %s, want the tool's raw output", out)
+	}
+}
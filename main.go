@@ -2,45 +2,324 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
-	"sync"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	bedrock "github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	goredis "github.com/redis/go-redis/v9"
+
+	"ai-chatbot/internal/pow"
+	"ai-chatbot/internal/rag"
+	"ai-chatbot/internal/router"
+	"ai-chatbot/internal/session"
+	"ai-chatbot/internal/tools"
 )
 
 type ChatRequest struct {
 	Message string `json:"message"`
+	Stream  bool   `json:"stream,omitempty"`
+	UseRAG  bool   `json:"use_rag,omitempty"`
 }
 
 type ChatResponse struct {
-	Response string `json:"response"`
+	Response string   `json:"response"`
+	Sources  []string `json:"sources,omitempty"`
 }
 
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
+// Message is kept as an alias so existing call sites reading "Message" in
+// this package still make sense; the data itself lives in session.Store.
+type Message = session.Message
+
+const sessionCookieName = "session_id"
 
 var (
-	store = struct {
-		sync.RWMutex
-		m []Message
-	}{}
 	modelID = os.Getenv("MODEL_ID")
 	region  = os.Getenv("AWS_REGION")
+
+	// sessions holds every conversation, keyed by session ID. Backend is
+	// selected by STORE_BACKEND (memory, bolt, redis); defaults to memory.
+	sessions session.Store
+
+	// rt is non-nil only when ROUTER_CONFIG points at a valid config file;
+	// otherwise /chat falls back to the single-model modelID behavior.
+	rt *router.Router
+
+	// powVerifier gates POST /chat behind a proof-of-work puzzle so that
+	// expensive Bedrock calls aren't free to abuse without an account.
+	powVerifier *pow.Verifier
+
+	// toolRegistry holds the tools the model may call mid-conversation.
+	toolRegistry = tools.NewRegistry()
+
+	// ragStore holds uploaded documents for retrieval-augmented chat.
+	ragStore *rag.Store
 )
 
+// ragTopK is how many chunks are retrieved per RAG-enabled chat turn.
+const ragTopK = 4
+
+// defaultEmbeddingsModelID is used when EMBEDDINGS_MODEL_ID isn't set.
+const defaultEmbeddingsModelID = "amazon.titan-embed-text-v2:0"
+
+func newRagStore(br *bedrock.Client) *rag.Store {
+	embeddingsModelID := os.Getenv("EMBEDDINGS_MODEL_ID")
+	if embeddingsModelID == "" {
+		embeddingsModelID = defaultEmbeddingsModelID
+	}
+
+	embedder := rag.NewBedrockEmbedder(br, embeddingsModelID)
+	chunking := rag.ChunkConfig{
+		Size:      envInt("RAG_CHUNK_SIZE", 1000),
+		Overlap:   envInt("RAG_CHUNK_OVERLAP", 200),
+		MaxChunks: envInt("RAG_MAX_CHUNKS_PER_DOC", 500),
+	}
+	return rag.NewStore(rag.NewMemoryVectorStore(), embedder, chunking)
+}
+
+// maxToolDepth bounds how many tool-use round trips a single /chat request
+// may take before the handler gives up and returns an error.
+const maxToolDepth = 8
+
+// apiContentBlock is a single Anthropic content block. It's kept as a raw
+// map (rather than a typed struct) because block shape varies by type
+// (text, tool_use, tool_result).
+type apiContentBlock map[string]interface{}
+
+// apiMessage is one turn in the Bedrock request/response "messages" array.
+type apiMessage struct {
+	Role    string            `json:"role"`
+	Content []apiContentBlock `json:"content"`
+}
+
+func registerTools() {
+	var allowedHosts []string
+	if v := os.Getenv("TOOL_FETCH_ALLOWED_HOSTS"); v != "" {
+		allowedHosts = strings.Split(v, ",")
+	}
+	toolRegistry.Register(tools.NewFetchTool(allowedHosts))
+	toolRegistry.Register(tools.NewCalculatorTool())
+}
+
+// invokeModel sends a single request to Bedrock, going through the router
+// when one is configured and falling back to the single-model client.
+func invokeModel(ctx context.Context, br *bedrock.Client, body []byte) (*bedrock.InvokeModelOutput, error) {
+	if rt != nil {
+		out, _, err := rt.Invoke(ctx, body)
+		return out, err
+	}
+	return br.InvokeModel(ctx, &bedrock.InvokeModelInput{
+		Body:        body,
+		ModelId:     &modelID,
+		ContentType: awsString("application/json"),
+	})
+}
+
+// invokeModelStream is the streaming counterpart to invokeModel: same
+// router-first, single-model-fallback behavior, for the SSE /chat path.
+func invokeModelStream(ctx context.Context, br *bedrock.Client, body []byte) (*bedrock.InvokeModelWithResponseStreamOutput, error) {
+	if rt != nil {
+		out, _, err := rt.InvokeStream(ctx, body)
+		return out, err
+	}
+	return br.InvokeModelWithResponseStream(ctx, &bedrock.InvokeModelWithResponseStreamInput{
+		Body:        body,
+		ModelId:     &modelID,
+		ContentType: awsString("application/json"),
+	})
+}
+
+// runChatLoop drives the tool-use protocol: it invokes the model, and for
+// as long as the model asks to use a tool, executes it locally and feeds
+// the result back, until the model returns a normal text stop or
+// maxToolDepth round trips are exhausted.
+func runChatLoop(ctx context.Context, br *bedrock.Client, messages []apiMessage, system string) (string, error) {
+	reqBody := map[string]interface{}{
+		"messages":          messages,
+		"max_tokens":        1024,
+		"temperature":       0.3,
+		"anthropic_version": "bedrock-2023-05-31",
+	}
+	if specs := toolRegistry.Specs(); specs != nil {
+		reqBody["tools"] = specs
+	}
+	if system != "" {
+		reqBody["system"] = system
+	}
+
+	for depth := 0; depth < maxToolDepth; depth++ {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return "", fmt.Errorf("marshal request: %w", err)
+		}
+
+		out, err := invokeModel(ctx, br, b)
+		if err != nil {
+			return "", fmt.Errorf("invoke model: %w", err)
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(out.Body, &parsed); err != nil {
+			return "", fmt.Errorf("parse model response: %w", err)
+		}
+
+		if stopReason, _ := parsed["stop_reason"].(string); stopReason != "tool_use" {
+			text := extractAssistantText(parsed)
+			if text == "" {
+				text = "(no text returned)"
+			}
+			return text, nil
+		}
+
+		rawBlocks, _ := parsed["content"].([]interface{})
+		messages = append(messages, apiMessage{Role: "assistant", Content: asContentBlocks(rawBlocks)})
+		messages = append(messages, apiMessage{Role: "user", Content: runTools(ctx, rawBlocks)})
+		reqBody["messages"] = messages
+	}
+
+	return "", fmt.Errorf("exceeded max tool-call depth (%d)", maxToolDepth)
+}
+
+// retrieveContext embeds the query and returns a system prompt built from
+// the top-K matching chunks, along with the IDs of the chunks cited, so
+// the caller can surface them as sources.
+func retrieveContext(ctx context.Context, query string) (system string, sourceIDs []string, err error) {
+	chunks, err := ragStore.Query(ctx, query, ragTopK)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(chunks) == 0 {
+		return "", nil, nil
+	}
+
+	var b strings.Builder
+	b.WriteString("Use the following retrieved context to answer the user's question. Cite only what's relevant.\n\n")
+	for _, c := range chunks {
+		fmt.Fprintf(&b, "[%s]: %s\n\n", c.ID, c.Text)
+		sourceIDs = append(sourceIDs, c.ID)
+	}
+	return b.String(), sourceIDs, nil
+}
+
+func asContentBlocks(raw []interface{}) []apiContentBlock {
+	blocks := make([]apiContentBlock, 0, len(raw))
+	for _, r := range raw {
+		if m, ok := r.(map[string]interface{}); ok {
+			blocks = append(blocks, apiContentBlock(m))
+		}
+	}
+	return blocks
+}
+
+// runTools executes every tool_use block in rawBlocks and returns the
+// corresponding tool_result blocks, in order.
+func runTools(ctx context.Context, rawBlocks []interface{}) []apiContentBlock {
+	var results []apiContentBlock
+	for _, r := range rawBlocks {
+		block, ok := r.(map[string]interface{})
+		if !ok || block["type"] != "tool_use" {
+			continue
+		}
+
+		name, _ := block["name"].(string)
+		id, _ := block["id"].(string)
+		input, _ := json.Marshal(block["input"])
+
+		start := time.Now()
+		output := toolRegistry.Invoke(ctx, name, input)
+		log.Printf("tool %s invoked in %s", name, time.Since(start))
+
+		results = append(results, apiContentBlock{
+			"type":        "tool_result",
+			"tool_use_id": id,
+			"content":     string(output),
+		})
+	}
+	return results
+}
+
+// newPowVerifier builds the PoW verifier from POW_SECRET (when unset, an
+// insecure built-in secret is used and a warning is logged),
+// POW_DIFFICULTY (default 20 leading zero bits), and POW_TTL_SECONDS
+// (default 300s).
+func newPowVerifier() *pow.Verifier {
+	secret := os.Getenv("POW_SECRET")
+	if secret == "" {
+		secret = "dev-only-insecure-pow-secret"
+		log.Println("POW_SECRET not set; using an insecure default, do not use in production")
+	}
+	return pow.NewVerifier([]byte(secret), envInt("POW_DIFFICULTY", 20), time.Duration(envInt("POW_TTL_SECONDS", 300))*time.Second)
+}
+
+// newStore builds the configured session.Store backend.
+func newStore() (session.Store, error) {
+	limits := session.Limits{
+		MaxMessages: envInt("SESSION_MAX_MESSAGES", 200),
+		MaxTokens:   envInt("SESSION_MAX_TOKENS", 8000),
+	}
+
+	switch os.Getenv("STORE_BACKEND") {
+	case "bolt":
+		path := os.Getenv("BOLT_PATH")
+		if path == "" {
+			path = "sessions.db"
+		}
+		return session.NewBoltStore(path, limits)
+	case "redis":
+		client := goredis.NewClient(&goredis.Options{Addr: os.Getenv("REDIS_ADDR")})
+		return session.NewRedisStore(client, limits), nil
+	default:
+		return session.NewMemoryStore(limits), nil
+	}
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// sessionID resolves the caller's session from X-Session-ID or the
+// session_id cookie, minting a fresh one when neither is present.
+func sessionID(r *http.Request) (id string, generated bool) {
+	if h := r.Header.Get("X-Session-ID"); h != "" {
+		return h, false
+	}
+	if c, err := r.Cookie(sessionCookieName); err == nil && c.Value != "" {
+		return c.Value, false
+	}
+	return newSessionID(), true
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Session-ID, X-PoW-Solution")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -67,13 +346,138 @@ func main() {
 
 	br := bedrock.NewFromConfig(cfg)
 
+	var storeErr error
+	sessions, storeErr = newStore()
+	if storeErr != nil {
+		log.Fatalf("unable to init session store: %v", storeErr)
+	}
+
+	if path := os.Getenv("ROUTER_CONFIG"); path != "" {
+		routerCfg, err := router.LoadConfig(path)
+		if err != nil {
+			log.Fatalf("unable to load router config: %v", err)
+		}
+		rt = router.New(br, routerCfg)
+		log.Printf("router enabled with %d backend(s) from %s", len(routerCfg.Backends), path)
+	}
+
+	powVerifier = newPowVerifier()
+	registerTools()
+	ragStore = newRagStore(br)
+
 	chatMux := http.NewServeMux()
 
 	chatMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("ok"))
 	})
 
-	chatMux.HandleFunc("/chat", func(w http.ResponseWriter, r *http.Request) {
+	chatMux.HandleFunc("GET /pow/challenge", pow.ChallengeHandler(powVerifier))
+
+	chatMux.HandleFunc("/router/status", func(w http.ResponseWriter, r *http.Request) {
+		if rt == nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("[]"))
+			return
+		}
+		statusB, err := json.Marshal(rt.Status())
+		if err != nil {
+			http.Error(w, "internal response error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(statusB)
+	})
+
+	// GET/DELETE /sessions/{id} only ever operate on the caller's own
+	// session, derived the same way /chat derives it (X-Session-ID header
+	// or session_id cookie). There's no notion of listing or addressing
+	// someone else's session by ID without real authn, so the {id} path
+	// parameter is checked against that, not trusted on its own. There is
+	// no GET /sessions: Store.List returns every session ID known to the
+	// backend, not just the caller's, and a session ID doubles as the
+	// bearer credential for reading that session's history, so handing
+	// the full list to any caller would leak every other session's
+	// content. Nothing here tracks which sessions belong to which caller,
+	// so there's no subset of List's result that's safe to expose.
+	chatMux.HandleFunc("GET /sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		sid, _ := sessionID(r)
+		if r.PathValue("id") != sid {
+			http.Error(w, "not your session", http.StatusForbidden)
+			return
+		}
+		msgs, err := sessions.Get(r.Context(), sid)
+		if err != nil {
+			http.Error(w, "failed to read session: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		respB, _ := json.Marshal(msgs)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(respB)
+	})
+
+	chatMux.HandleFunc("DELETE /sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		sid, _ := sessionID(r)
+		if r.PathValue("id") != sid {
+			http.Error(w, "not your session", http.StatusForbidden)
+			return
+		}
+		if err := sessions.Delete(r.Context(), sid); err != nil {
+			http.Error(w, "failed to delete session: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	chatMux.HandleFunc("POST /docs", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(io.LimitReader(file, 32<<20))
+		if err != nil {
+			http.Error(w, "failed to read upload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		text, err := rag.ExtractText(header.Filename, data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		doc, err := ragStore.AddDocument(r.Context(), header.Filename, text)
+		if errors.Is(err, rag.ErrTooManyChunks) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to index document: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		respB, _ := json.Marshal(doc)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(respB)
+	})
+
+	chatMux.HandleFunc("GET /docs", func(w http.ResponseWriter, r *http.Request) {
+		respB, _ := json.Marshal(ragStore.ListDocuments())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(respB)
+	})
+
+	chatMux.HandleFunc("DELETE /docs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := ragStore.DeleteDocument(r.Context(), r.PathValue("id")); err != nil {
+			http.Error(w, "failed to delete document: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	chatHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "only POST", http.StatusMethodNotAllowed)
 			return
@@ -85,69 +489,82 @@ func main() {
 			return
 		}
 
-		store.Lock()
-		store.m = append(store.m, Message{Role: "user", Content: cr.Message})
-		store.Unlock()
+		sid, generated := sessionID(r)
+		if generated {
+			http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: sid, Path: "/"})
+		}
 
-		type contentBlock struct {
-			Type string `json:"type"`
-			Text string `json:"text"`
+		if err := sessions.Append(r.Context(), sid, Message{Role: "user", Content: cr.Message}); err != nil {
+			http.Error(w, "failed to save message: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
-		type inMessage struct {
-			Role    string         `json:"role"`
-			Content []contentBlock `json:"content"`
+
+		history, err := sessions.Get(r.Context(), sid)
+		if err != nil {
+			http.Error(w, "failed to load session: "+err.Error(), http.StatusInternalServerError)
+			return
 		}
-		var messagesPayload []inMessage
-		store.RLock()
-		for _, m := range store.m {
-			messagesPayload = append(messagesPayload, inMessage{
-				Role:    m.Role,
-				Content: []contentBlock{{Type: "text", Text: m.Content}},
-			})
+
+		var system string
+		var sources []string
+		if cr.UseRAG {
+			system, sources, err = retrieveContext(r.Context(), cr.Message)
+			if err != nil {
+				http.Error(w, "rag retrieval failed: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
 		}
-		store.RUnlock()
 
-		reqBody := map[string]interface{}{
-			"messages":          messagesPayload,
-			"max_tokens":        1024,
-			"temperature":       0.3,
-			"anthropic_version": "bedrock-2023-05-31",
+		if cr.Stream {
+			type contentBlock struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			}
+			type inMessage struct {
+				Role    string         `json:"role"`
+				Content []contentBlock `json:"content"`
+			}
+			var messagesPayload []inMessage
+			for _, m := range history {
+				messagesPayload = append(messagesPayload, inMessage{
+					Role:    m.Role,
+					Content: []contentBlock{{Type: "text", Text: m.Content}},
+				})
+			}
+			reqBody := map[string]interface{}{
+				"messages":          messagesPayload,
+				"max_tokens":        1024,
+				"temperature":       0.3,
+				"anthropic_version": "bedrock-2023-05-31",
+			}
+			if system != "" {
+				reqBody["system"] = system
+			}
+			streamChat(w, r, br, sid, reqBody, sources)
+			return
 		}
-		b, _ := json.Marshal(reqBody)
 
-		input := &bedrock.InvokeModelInput{
-			Body:        b,
-			ModelId:     &modelID,
-			ContentType: awsString("application/json"),
+		var messages []apiMessage
+		for _, m := range history {
+			messages = append(messages, apiMessage{
+				Role:    m.Role,
+				Content: []apiContentBlock{{"type": "text", "text": m.Content}},
+			})
 		}
 
-		out, err := br.InvokeModel(r.Context(), input)
+		assistantText, err := runChatLoop(r.Context(), br, messages, system)
 		if err != nil {
-			log.Printf("InvokeModel error: %v", err)
+			log.Printf("chat loop error: %v", err)
 			http.Error(w, "model error: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		var parsed map[string]interface{}
-		if err := json.Unmarshal(out.Body, &parsed); err != nil {
-			log.Printf("failed to parse model response: %v", err)
-			http.Error(w, "failed to parse model response", http.StatusInternalServerError)
+		if err := sessions.Append(r.Context(), sid, Message{Role: "assistant", Content: assistantText}); err != nil {
+			http.Error(w, "failed to save message: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		log.Printf("Raw model response: %s", string(out.Body))
-		log.Println("Testing SAST")
-
-		assistantText := extractAssistantText(parsed)
-		if assistantText == "" {
-			assistantText = "(no text returned)"
-		}
-
-		store.Lock()
-		store.m = append(store.m, Message{Role: "assistant", Content: assistantText})
-		store.Unlock()
-
-		resp := ChatResponse{Response: assistantText}
+		resp := ChatResponse{Response: assistantText, Sources: sources}
 		respB, err := json.Marshal(resp)
 		if err != nil {
 			http.Error(w, "internal response error", http.StatusInternalServerError)
@@ -159,6 +576,8 @@ func main() {
 		_, _ = w.Write(respB)
 	})
 
+	chatMux.Handle("/chat", pow.Middleware(powVerifier)(chatHandler))
+
 	handlerWithCORS := corsMiddleware(chatMux)
 
 	port := os.Getenv("PORT")
@@ -178,6 +597,98 @@ func main() {
 
 func awsString(s string) *string { return &s }
 
+// streamChat invokes the model via the Bedrock response-stream API and relays
+// each text delta to the client as a server-sent event. The full assistant
+// reply is only appended to the store once the stream completes normally.
+// sources, if non-empty, is the set of RAG chunk IDs cited for this turn; it
+// is sent as a single "sources" event ahead of the text deltas, since it's
+// known up front and doesn't arrive incrementally like the model's reply.
+func streamChat(w http.ResponseWriter, r *http.Request, br *bedrock.Client, sid string, reqBody map[string]interface{}, sources []string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	b, _ := json.Marshal(reqBody)
+
+	out, err := invokeModelStream(r.Context(), br, b)
+	if err != nil {
+		log.Printf("InvokeModelWithResponseStream error: %v", err)
+		http.Error(w, "model error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer out.GetStream().Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if len(sources) > 0 {
+		fmt.Fprintf(w, "event: sources\ndata: %s\n\n", mustMarshal(struct {
+			Sources []string `json:"sources"`
+		}{Sources: sources}))
+		flusher.Flush()
+	}
+
+	var full string
+	for event := range out.GetStream().Events() {
+		chunk, ok := event.(*types.ResponseStreamMemberChunk)
+		if !ok {
+			continue
+		}
+
+		var evt struct {
+			Type  string `json:"type"`
+			Delta struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"delta"`
+		}
+		if err := json.Unmarshal(chunk.Value.Bytes, &evt); err != nil {
+			log.Printf("failed to parse stream event: %v", err)
+			continue
+		}
+
+		switch evt.Type {
+		case "content_block_delta":
+			if evt.Delta.Text == "" {
+				continue
+			}
+			full += evt.Delta.Text
+			fmt.Fprintf(w, "data: %s\n\n", mustMarshal(ChatResponse{Response: evt.Delta.Text}))
+			flusher.Flush()
+		case "message_stop":
+			fmt.Fprint(w, "event: done\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+
+	if err := out.GetStream().Err(); err != nil {
+		log.Printf("response stream error: %v", err)
+		return
+	}
+
+	if full == "" {
+		full = "(no text returned)"
+	}
+
+	if err := sessions.Append(r.Context(), sid, Message{Role: "assistant", Content: full}); err != nil {
+		log.Printf("failed to save streamed message: %v", err)
+	}
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("failed to marshal SSE payload: %v", err)
+		return []byte("{}")
+	}
+	return b
+}
+
 func extractAssistantText(parsed map[string]interface{}) string {
 	if text, ok := parsed["output_text"].(string); ok && text != "" {
 		return text
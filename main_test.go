@@ -0,0 +1,155 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestEnvInt(t *testing.T) {
+	const key = "TEST_ENV_INT_VALUE"
+
+	os.Unsetenv(key)
+	if got := envInt(key, 42); got != 42 {
+		t.Errorf("envInt() with unset var = %d, want default 42", got)
+	}
+
+	t.Setenv(key, "7")
+	if got := envInt(key, 42); got != 7 {
+		t.Errorf("envInt() = %d, want 7", got)
+	}
+
+	t.Setenv(key, "not-a-number")
+	if got := envInt(key, 42); got != 42 {
+		t.Errorf("envInt() with malformed var = %d, want default 42", got)
+	}
+}
+
+func TestSessionIDPrefersHeaderOverCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/sessions/x", nil)
+	r.Header.Set("X-Session-ID", "from-header")
+	r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "from-cookie"})
+
+	id, generated := sessionID(r)
+	if id != "from-header" || generated {
+		t.Errorf("sessionID() = (%q, %v), want (\"from-header\", false)", id, generated)
+	}
+}
+
+func TestSessionIDFallsBackToCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/sessions/x", nil)
+	r.AddCookie(&http.Cookie{Name: sessionCookieName, Value: "from-cookie"})
+
+	id, generated := sessionID(r)
+	if id != "from-cookie" || generated {
+		t.Errorf("sessionID() = (%q, %v), want (\"from-cookie\", false)", id, generated)
+	}
+}
+
+func TestSessionIDGeneratesWhenNeitherPresent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/sessions/x", nil)
+
+	id, generated := sessionID(r)
+	if id == "" || !generated {
+		t.Errorf("sessionID() = (%q, %v), want a non-empty generated id", id, generated)
+	}
+
+	id2, _ := sessionID(httptest.NewRequest(http.MethodGet, "/sessions/x", nil))
+	if id == id2 {
+		t.Errorf("sessionID() generated the same id twice: %q", id)
+	}
+}
+
+func TestMustMarshalReturnsEmptyObjectOnFailure(t *testing.T) {
+	// channels aren't JSON-marshalable, so this exercises the fallback path.
+	got := mustMarshal(make(chan int))
+	if string(got) != "{}" {
+		t.Errorf("mustMarshal() = %s, want {}", got)
+	}
+}
+
+func TestMustMarshalEncodesValue(t *testing.T) {
+	got := mustMarshal(ChatResponse{Response: "hi"})
+	want := `{"response":"hi"}`
+	if string(got) != want {
+		t.Errorf("mustMarshal() = %s, want %s", got, want)
+	}
+}
+
+func TestExtractAssistantTextOutputText(t *testing.T) {
+	got := extractAssistantText(map[string]interface{}{"output_text": "hello"})
+	if got != "hello" {
+		t.Errorf("extractAssistantText() = %q, want %q", got, "hello")
+	}
+}
+
+func TestExtractAssistantTextChoicesMessageContent(t *testing.T) {
+	parsed := map[string]interface{}{
+		"choices": []interface{}{
+			map[string]interface{}{
+				"message": map[string]interface{}{
+					"content": []interface{}{
+						map[string]interface{}{"text": "from choices"},
+					},
+				},
+			},
+		},
+	}
+	if got := extractAssistantText(parsed); got != "from choices" {
+		t.Errorf("extractAssistantText() = %q, want %q", got, "from choices")
+	}
+}
+
+func TestExtractAssistantTextChoicesBareText(t *testing.T) {
+	parsed := map[string]interface{}{
+		"choices": []interface{}{
+			map[string]interface{}{"text": "bare text"},
+		},
+	}
+	if got := extractAssistantText(parsed); got != "bare text" {
+		t.Errorf("extractAssistantText() = %q, want %q", got, "bare text")
+	}
+}
+
+func TestExtractAssistantTextTopLevelContent(t *testing.T) {
+	parsed := map[string]interface{}{
+		"content": []interface{}{
+			map[string]interface{}{"text": "top level"},
+		},
+	}
+	if got := extractAssistantText(parsed); got != "top level" {
+		t.Errorf("extractAssistantText() = %q, want %q", got, "top level")
+	}
+}
+
+func TestExtractAssistantTextUnrecognizedShapeIsEmpty(t *testing.T) {
+	if got := extractAssistantText(map[string]interface{}{"unexpected": true}); got != "" {
+		t.Errorf("extractAssistantText() = %q, want empty string", got)
+	}
+}
+
+func TestCorsMiddlewareAllowsPoWAndSessionHeaders(t *testing.T) {
+	handler := corsMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodOptions, "/chat", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	allow := w.Header().Get("Access-Control-Allow-Headers")
+	for _, want := range []string{"X-PoW-Solution", "X-Session-ID"} {
+		found := false
+		for _, h := range strings.Split(allow, ",") {
+			if strings.TrimSpace(h) == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Access-Control-Allow-Headers = %q, want it to include %q", allow, want)
+		}
+	}
+}